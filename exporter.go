@@ -1,44 +1,41 @@
 package excel_exporter
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"sync"
 
 	"github.com/xuri/excelize/v2"
+	"golang.org/x/sync/errgroup"
 )
 
 // SheetMaxRows defines the maximum number of rows per sheet for Excel 2007 and later versions (.xlsx format).
 const SheetMaxRows = 1048576
 
-// MergeCell defines a merged cell data.
-type MergeCell struct {
-	TopLeftCell     string
-	BottomRightCell string
-}
-
-// Row represents a row of data in the Excel sheet.
-type Row struct {
-	Cells      []excelize.Cell    // Cells in the row
-	MergeCells []MergeCell        // Merged cells in the row
-	RowOpts    []excelize.RowOpts // Options for the row, only useful when useStreamWriter is true
-}
-
-// RowDataFunc is a function type that returns the next row of data or nil if no more data.
-type RowDataFunc func() Row
-
-// SheetData represents the data for a single sheet.
-type SheetData struct {
-	Name    string
-	RowFunc RowDataFunc
-}
-
 // Exporter provides methods for exporting data to Excel files.
 type Exporter struct {
 	File            *excelize.File
 	FileName        string
-	CurrentSheet    string // Current sheet name
+	UseStreamWriter bool
+}
+
+// SheetContext carries the state a sheet's write callbacks need: which
+// sheet they're writing to and, in stream mode, that sheet's own
+// StreamWriter. It replaces Exporter's old CurrentSheet and StreamWriter
+// fields now that ExportConcurrent may have several sheets in flight at
+// once, each with its own StreamWriter, sharing only the underlying File.
+// Mu guards every direct read or write of File/StreamWriter made outside a
+// RowDataFunc's own writeRowFunc call (e.g. applyColWidths, or a caller
+// reaching into ctx.File/ctx.StreamWriter by hand) — excelize's File isn't
+// safe for concurrent access even across distinct sheets, so ExportConcurrent
+// shares one Mu across every sheet it's writing.
+type SheetContext struct {
+	File            *excelize.File
+	Name            string
 	UseStreamWriter bool
 	StreamWriter    *excelize.StreamWriter
+	Mu              *sync.Mutex
 }
 
 // New creates a new Exporter instance.
@@ -50,54 +47,119 @@ func New(fileName string, useStreamWriter bool) *Exporter {
 	}
 }
 
-// Export exports the Excel file.
+// Export exports the Excel file, writing each sheet in order.
 func (e *Exporter) Export(sheets []SheetData) error {
+	var mu sync.Mutex
+
 	for i, sheet := range sheets {
-		if _, err := e.File.NewSheet(sheet.Name); err != nil {
-			return fmt.Errorf("failed to create a new sheet: %w", err)
+		if err := e.exportSheet(&mu, sheet, i == 0); err != nil {
+			return err
 		}
+	}
 
-		// delete default sheet
-		if i == 0 && e.File.SheetCount > 1 {
-			if err := e.File.DeleteSheet("Sheet1"); err != nil {
-				return fmt.Errorf("failed to delete default sheet: %w", err)
-			}
-		}
+	return e.File.SaveAs(e.FileName)
+}
 
-		if e.UseStreamWriter {
-			if err := e.exportUsingStreamWriter(sheet); err != nil {
-				return err
-			}
-		} else {
-			if err := e.exportUsingMemory(sheet); err != nil {
-				return err
-			}
-		}
+// ExportConcurrent writes sheets across workers goroutines at once, using
+// errgroup to run them and report the first error. In stream mode each
+// sheet gets its own StreamWriter via File.NewStreamWriter, and separate
+// StreamWriters target separate sheets so their row production and flush
+// run fully in parallel. Sheet/StreamWriter creation and, in memory mode,
+// every cell write still touch the single shared *excelize.File, so those
+// operations are serialized under a mutex; memory mode therefore sees
+// little benefit from workers beyond overlapping RowFunc production with
+// writes. Use Export instead if the sheets don't need to run concurrently.
+func (e *Exporter) ExportConcurrent(sheets []SheetData, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	g, _ := errgroup.WithContext(context.Background())
+	g.SetLimit(workers)
+
+	for i, sheet := range sheets {
+		sheet, isFirst := sheet, i == 0
+		g.Go(func() error {
+			return e.exportSheet(&mu, sheet, isFirst)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
 	}
 
 	return e.File.SaveAs(e.FileName)
 }
 
-func (e *Exporter) exportUsingStreamWriter(sheet SheetData) error {
-	initFunc := func(sheetName string) error {
-		var err error
-		e.StreamWriter, err = e.File.NewStreamWriter(sheetName)
+// exportSheet creates sheet (deleting excelize's default "Sheet1" once the
+// first real sheet exists) and writes its rows, in stream or memory mode.
+// mu guards every mutation of the shared *excelize.File so sheets can be
+// exported concurrently by ExportConcurrent.
+func (e *Exporter) exportSheet(mu *sync.Mutex, sheet SheetData, isFirst bool) error {
+	mu.Lock()
+	if _, err := e.File.NewSheet(sheet.Name); err != nil {
+		mu.Unlock()
+		return fmt.Errorf("failed to create a new sheet: %w", err)
+	}
+	if isFirst && e.File.SheetCount > 1 {
+		if err := e.File.DeleteSheet("Sheet1"); err != nil {
+			mu.Unlock()
+			return fmt.Errorf("failed to delete default sheet: %w", err)
+		}
+	}
+	mu.Unlock()
+
+	ctx, err := newSheetContext(mu, e.File, sheet.Name, e.UseStreamWriter, false)
+	if err != nil {
 		return err
 	}
 
-	writeRowFunc := func(sheetName string, rowID int, row Row) error {
+	if e.UseStreamWriter {
+		return e.writeSheetStream(mu, sheet, ctx)
+	}
+	return e.writeSheetMemory(mu, sheet, ctx)
+}
+
+// newSheetContext builds the SheetContext for name, optionally creating the
+// sheet first (for the overflow sheets exportHelper creates mid-stream) and,
+// in stream mode, its StreamWriter. Both operations mutate the shared File,
+// so they run under mu.
+func newSheetContext(mu *sync.Mutex, file *excelize.File, name string, useStreamWriter, createSheet bool) (*SheetContext, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if createSheet {
+		if _, err := file.NewSheet(name); err != nil {
+			return nil, fmt.Errorf("failed to create a new sheet: %w", err)
+		}
+	}
+
+	ctx := &SheetContext{File: file, Name: name, UseStreamWriter: useStreamWriter, Mu: mu}
+	if useStreamWriter {
+		sw, err := file.NewStreamWriter(name)
+		if err != nil {
+			return nil, err
+		}
+		ctx.StreamWriter = sw
+	}
+	return ctx, nil
+}
+
+func (e *Exporter) writeSheetStream(mu *sync.Mutex, sheet SheetData, ctx *SheetContext) error {
+	writeRowFunc := func(ctx *SheetContext, rowID int, row Row) error {
 		rowCells := make([]interface{}, len(row.Cells))
 		for j, cell := range row.Cells {
 			rowCells[j] = cell
 		}
 
 		cell, _ := excelize.CoordinatesToCellName(1, rowID)
-		if err := e.StreamWriter.SetRow(cell, rowCells, row.RowOpts...); err != nil {
+		if err := ctx.StreamWriter.SetRow(cell, rowCells, row.RowOpts...); err != nil {
 			return err
 		}
 
 		for _, mergeCell := range row.MergeCells {
-			if err := e.StreamWriter.MergeCell(mergeCell.TopLeftCell, mergeCell.BottomRightCell); err != nil {
+			if err := ctx.StreamWriter.MergeCell(mergeCell.TopLeftCell, mergeCell.BottomRightCell); err != nil {
 				return err
 			}
 		}
@@ -105,40 +167,57 @@ func (e *Exporter) exportUsingStreamWriter(sheet SheetData) error {
 		return nil
 	}
 
-	if err := e.exportHelper(sheet, initFunc, writeRowFunc); err != nil {
+	rows, cols, err := e.exportHelper(mu, sheet, ctx, writeRowFunc)
+	if err != nil {
 		return err
 	}
 
-	return e.StreamWriter.Flush()
-}
+	if sheet.Options != nil {
+		if err := applySheetDataValidations(mu, e.File, sheet.Name, sheet.Options.DataValidations); err != nil {
+			return err
+		}
+	}
 
-func (e *Exporter) exportUsingMemory(sheet SheetData) error {
-	initFunc := func(sheetName string) error {
-		return nil
+	mu.Lock()
+	err = ctx.StreamWriter.Flush()
+	mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := applySheetOptions(mu, e.File, sheet.Name, sheet.Options, cols); err != nil {
+		return err
 	}
 
-	writeRowFunc := func(sheetName string, rowID int, row Row) error {
+	return e.applyTable(mu, sheet.Name, sheet.Table, rows, cols)
+}
+
+func (e *Exporter) writeSheetMemory(mu *sync.Mutex, sheet SheetData, ctx *SheetContext) error {
+	writeRowFunc := func(ctx *SheetContext, rowID int, row Row) error {
+		mu.Lock()
+		defer mu.Unlock()
+
 		for j, cell := range row.Cells {
 			cellName, _ := excelize.CoordinatesToCellName(j+1, rowID)
-			if err := e.File.SetCellValue(sheetName, cellName, cell.Value); err != nil {
+			if err := ctx.File.SetCellValue(ctx.Name, cellName, cell.Value); err != nil {
 				return err
 			}
 
 			if cell.StyleID > 0 {
-				if err := e.File.SetCellStyle(sheetName, cellName, cellName, cell.StyleID); err != nil {
+				if err := ctx.File.SetCellStyle(ctx.Name, cellName, cellName, cell.StyleID); err != nil {
 					return err
 				}
 			}
 
 			if cell.Formula != "" {
-				if err := e.File.SetCellFormula(sheetName, cellName, cell.Formula); err != nil {
+				if err := ctx.File.SetCellFormula(ctx.Name, cellName, cell.Formula); err != nil {
 					return err
 				}
 			}
 		}
 
 		for _, mergeCell := range row.MergeCells {
-			if err := e.File.MergeCell(sheetName, mergeCell.TopLeftCell, mergeCell.BottomRightCell); err != nil {
+			if err := ctx.File.MergeCell(ctx.Name, mergeCell.TopLeftCell, mergeCell.BottomRightCell); err != nil {
 				return err
 			}
 		}
@@ -146,67 +225,139 @@ func (e *Exporter) exportUsingMemory(sheet SheetData) error {
 		return nil
 	}
 
-	return e.exportHelper(sheet, initFunc, writeRowFunc)
-}
+	rows, cols, err := e.exportHelper(mu, sheet, ctx, writeRowFunc)
+	if err != nil {
+		return err
+	}
 
-func (e *Exporter) exportHelper(sheet SheetData, initFunc func(string) error, writeRowFunc func(string, int, Row) error) error {
-	rowID := 1
-	sheetSuffix := 0
-	e.CurrentSheet = sheet.Name
+	if sheet.Options != nil {
+		if err := applySheetDataValidations(mu, e.File, sheet.Name, sheet.Options.DataValidations); err != nil {
+			return err
+		}
+	}
 
-	if err := initFunc(e.CurrentSheet); err != nil {
+	if err := applySheetOptions(mu, e.File, sheet.Name, sheet.Options, cols); err != nil {
 		return err
 	}
 
+	return e.applyTable(mu, sheet.Name, sheet.Table, rows, cols)
+}
+
+// exportHelper drives sheet.RowFunc(ctx) into writeRowFunc, splitting into
+// "<name>_<n>" overflow sheets once SheetMaxRows is reached. It returns the
+// row and column count written to the primary sheet (before any overflow),
+// which callers use to compute the range for sheet.Table.
+func (e *Exporter) exportHelper(mu *sync.Mutex, sheet SheetData, ctx *SheetContext, writeRowFunc func(*SheetContext, int, Row) error) (rows int, cols int, err error) {
+	rowFunc := sheet.RowFunc(ctx)
+
+	rowID := 1
+	sheetSuffix := 0
+	primaryRows := 0
+	primaryRecorded := false
+
 	for {
-		row := sheet.RowFunc()
+		row, err := rowFunc()
+		if err != nil {
+			return 0, 0, err
+		}
 		if row.Cells == nil {
 			break
 		}
 
+		if cols == 0 {
+			cols = len(row.Cells)
+		}
+
 		if rowID > SheetMaxRows {
+			if !primaryRecorded {
+				primaryRows = rowID - 1
+				primaryRecorded = true
+			}
+
 			// Create a new sheet if row count exceeds SheetMaxRows
 			sheetSuffix++
 			rowID = 1
 
-			currentSheetName := fmt.Sprintf("%s_%d", sheet.Name, sheetSuffix)
-			if _, err := e.File.NewSheet(currentSheetName); err != nil {
-				return fmt.Errorf("failed to create a new sheet: %w", err)
-			}
-
-			e.CurrentSheet = currentSheetName
-			if err := initFunc(e.CurrentSheet); err != nil {
-				return err
+			overflowName := fmt.Sprintf("%s_%d", sheet.Name, sheetSuffix)
+			overflowCtx, err := newSheetContext(mu, e.File, overflowName, e.UseStreamWriter, true)
+			if err != nil {
+				return 0, 0, err
 			}
+			ctx = overflowCtx
 		}
 
-		if err := writeRowFunc(e.CurrentSheet, rowID, row); err != nil {
-			return err
+		if err := writeRowFunc(ctx, rowID, row); err != nil {
+			return 0, 0, err
 		}
 
 		rowID++
 	}
 
-	return nil
+	if !primaryRecorded {
+		primaryRows = rowID - 1
+	}
+
+	return primaryRows, cols, nil
 }
 
-// UseRowChan returns a RowDataFunc that will use a channel to send Row objects to the given function
-func UseRowChan(sendDataFunc func(dataCh chan Row)) RowDataFunc {
-	var once sync.Once
-	var dataCh chan Row
-	return func() Row {
-		once.Do(func() {
-			dataCh = make(chan Row)
-			go func() {
-				defer close(dataCh)
-				sendDataFunc(dataCh)
-			}()
-		})
+// UseRowChan returns a RowDataFunc that streams rows produced by
+// sendDataFunc on a background goroutine. sendDataFunc is handed a context
+// that is canceled once the sheet is fully read, so a producer that selects
+// on ctx.Done() around its sends won't be left blocked forever if the
+// exporter stops pulling rows early (e.g. after a write error); as a
+// backstop for producers that ignore ctx, the context is also canceled once
+// the returned RowDataFunc becomes unreachable, via a GC finalizer. For that
+// finalizer to ever run, the goroutine below must not itself hold a
+// reference to the rowChanState it's canceling — it closes over dataCh/errCh
+// directly instead, so a producer blocked mid-send doesn't keep the state
+// (and therefore its own cancellation) permanently reachable.
+// A panic inside sendDataFunc is recovered and returned as an error instead
+// of crashing the process or deadlocking the pipeline.
+func UseRowChan(sendDataFunc func(ctx context.Context, dataCh chan<- Row) error) RowDataFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	dataCh := make(chan Row)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(dataCh)
+		errCh <- runProducer(ctx, sendDataFunc, dataCh)
+		close(errCh)
+	}()
+
+	state := &rowChanState{dataCh: dataCh, errCh: errCh, cancel: cancel}
+	runtime.SetFinalizer(state, func(s *rowChanState) { s.cancel() })
+
+	return state.next
+}
 
-		row, ok := <-dataCh
-		if !ok {
-			return Row{}
-		}
-		return row
+// rowChanState is the shared state behind a UseRowChan-produced RowDataFunc;
+// it exists so cancel can be reached from next without changing
+// RowDataFunc's signature.
+type rowChanState struct {
+	dataCh chan Row
+	errCh  chan error
+	cancel context.CancelFunc
+}
+
+func (s *rowChanState) next() (Row, error) {
+	row, ok := <-s.dataCh
+	if ok {
+		return row, nil
 	}
+
+	s.cancel()
+	return Row{}, <-s.errCh
+}
+
+// runProducer calls sendDataFunc, recovering any panic into an error so a
+// bug in user code can't leak the producer goroutine or deadlock the
+// exporter waiting on a send that will never come.
+func runProducer(ctx context.Context, sendDataFunc func(context.Context, chan<- Row) error, dataCh chan<- Row) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("excel_exporter: row producer panicked: %v", r)
+		}
+	}()
+	return sendDataFunc(ctx, dataCh)
 }
@@ -1,6 +1,7 @@
 package excel_exporter
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"runtime"
@@ -113,9 +114,12 @@ func TestExportWithStreamWriterUseChannel(t *testing.T) {
 	sheetNames := []string{"SheetA", "SheetB"}
 	sheets := make([]SheetData, len(sheetNames))
 	for i, name := range sheetNames {
+		name := name
 		sheets[i] = SheetData{
-			Name:    name,
-			RowFunc: UseRowChan(queryDataToChannelFunc(exporter, name)),
+			Name: name,
+			RowFunc: func(ctx *SheetContext) RowDataFunc {
+				return UseRowChan(queryDataToChannelFunc(ctx, name))
+			},
 		}
 	}
 
@@ -127,27 +131,29 @@ func TestExportWithStreamWriterUseChannel(t *testing.T) {
 	t.Logf("Export with StreamWriter channel took %v", duration)
 }
 
-func queryDataToChannelFunc(exporter *Exporter, sheetName string) func(dataCh chan Row) error {
-	return func(dataCh chan Row) error {
-		titleStyle, err := exporter.File.NewStyle(
+func queryDataToChannelFunc(ctx *SheetContext, sheetName string) func(ctx context.Context, dataCh chan<- Row) error {
+	return func(c context.Context, dataCh chan<- Row) error {
+		ctx.Mu.Lock()
+		titleStyle, err := ctx.File.NewStyle(
 			&excelize.Style{
 				Font:      &excelize.Font{Color: "777777", Size: 14},
 				Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
 			},
 		)
 		if err != nil {
+			ctx.Mu.Unlock()
 			return err
 		}
 
 		// Set column width
-		if exporter.UseStreamWriter {
-			if err = exporter.StreamWriter.SetColWidth(1, 3, 30); err != nil {
-				return err
-			}
+		if ctx.UseStreamWriter {
+			err = ctx.StreamWriter.SetColWidth(1, 3, 30)
 		} else {
-			if err = exporter.File.SetColWidth(exporter.CurrentSheet, "A", "C", 30); err != nil {
-				return err
-			}
+			err = ctx.File.SetColWidth(ctx.Name, "A", "C", 30)
+		}
+		ctx.Mu.Unlock()
+		if err != nil {
+			return err
 		}
 
 		dataCh <- Row{
@@ -174,29 +180,116 @@ func queryDataToChannelFunc(exporter *Exporter, sheetName string) func(dataCh ch
 
 		// Simulate querying data from the database and sending to channel
 		for i := 0; i < 10; i++ {
-			dataCh <- NewRow(
+			row := NewRow(
 				fmt.Sprintf("%s-%d-1", sheetName, i),
 				fmt.Sprintf("%s-%d-2", sheetName, i),
 				fmt.Sprintf("%s-%d-3", sheetName, i),
 			)
+			select {
+			case dataCh <- row:
+			case <-c.Done():
+				return c.Err()
+			}
 		}
 
 		return nil
 	}
 }
 
-func generateLargeData(sheetName string, rowCount int) RowDataFunc {
-	currentRow := 0
-	return func() (Row, error) {
-		if currentRow >= rowCount {
-			return Row{}, nil
+func generateLargeData(sheetName string, rowCount int) SheetRowFunc {
+	return func(*SheetContext) RowDataFunc {
+		currentRow := 0
+		return func() (Row, error) {
+			if currentRow >= rowCount {
+				return Row{}, nil
+			}
+			currentRow++
+			return NewRow(
+				fmt.Sprintf("%s-a%d", sheetName, currentRow),
+				fmt.Sprintf("%s-b%d", sheetName, currentRow),
+				fmt.Sprintf("%s-c%d", sheetName, currentRow),
+			), nil
+		}
+	}
+}
+
+// benchmarkSheets builds sheetCount sheets of rowsPerSheet rows each, named
+// so BenchmarkExportSerial and BenchmarkExportConcurrent write comparable
+// files.
+func benchmarkSheets(sheetCount, rowsPerSheet int) []SheetData {
+	sheets := make([]SheetData, sheetCount)
+	for i := range sheets {
+		name := fmt.Sprintf("Sheet%d", i)
+		sheets[i] = SheetData{Name: name, RowFunc: generateLargeData(name, rowsPerSheet)}
+	}
+	return sheets
+}
+
+func BenchmarkExportSerial(b *testing.B) {
+	const sheetCount, rowsPerSheet = 4, 5000
+
+	for i := 0; i < b.N; i++ {
+		exporter := New(fmt.Sprintf("bench_serial_%d.xlsx", i), true)
+		if err := exporter.Export(benchmarkSheets(sheetCount, rowsPerSheet)); err != nil {
+			b.Fatalf("Export failed: %v", err)
+		}
+		os.Remove(exporter.FileName)
+	}
+}
+
+func BenchmarkExportConcurrent(b *testing.B) {
+	const sheetCount, rowsPerSheet = 4, 5000
+
+	for i := 0; i < b.N; i++ {
+		exporter := New(fmt.Sprintf("bench_concurrent_%d.xlsx", i), true)
+		if err := exporter.ExportConcurrent(benchmarkSheets(sheetCount, rowsPerSheet), sheetCount); err != nil {
+			b.Fatalf("ExportConcurrent failed: %v", err)
+		}
+		os.Remove(exporter.FileName)
+	}
+}
+
+// TestUseRowChanCancelsProducerWhenConsumerStopsEarly exercises the deadlock
+// UseRowChan's GC-finalizer backstop exists to catch: a producer that selects
+// on ctx.Done() around its sends must unblock once the returned RowDataFunc
+// is dropped without being drained to completion, even though the producer
+// itself is what was holding the backstop's state reachable.
+func TestUseRowChanCancelsProducerWhenConsumerStopsEarly(t *testing.T) {
+	producerDone := make(chan struct{})
+
+	rowFunc := UseRowChan(func(ctx context.Context, dataCh chan<- Row) error {
+		defer close(producerDone)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case dataCh <- NewRow(i):
+			}
+		}
+	})
+
+	if _, err := rowFunc(); err != nil {
+		t.Fatalf("first row: %v", err)
+	}
+	if _, err := rowFunc(); err != nil {
+		t.Fatalf("second row: %v", err)
+	}
+
+	// Drop the only reference to the rowChanState behind rowFunc, then force
+	// enough GC cycles for its finalizer to run. Without the fix, the
+	// producer goroutine's own closure kept the state reachable forever.
+	rowFunc = nil
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-producerDone:
+			return
+		case <-deadline:
+			t.Fatal("producer goroutine was not canceled after rowFunc became unreachable")
+		default:
+			time.Sleep(10 * time.Millisecond)
 		}
-		currentRow++
-		return NewRow(
-			fmt.Sprintf("%s-a%d", sheetName, currentRow),
-			fmt.Sprintf("%s-b%d", sheetName, currentRow),
-			fmt.Sprintf("%s-c%d", sheetName, currentRow),
-		), nil
 	}
 }
 
@@ -0,0 +1,310 @@
+package excel_exporter
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Importer provides methods for reading data back out of Excel files,
+// mirroring Exporter's write path.
+type Importer struct {
+	File            *excelize.File
+	FileName        string
+	UseStreamReader bool
+}
+
+// NewImporter opens fileName for reading. When useStreamReader is true,
+// SheetReader pulls rows via excelize's Rows iterator so memory stays
+// bounded for million-row sheets; otherwise it loads each sheet fully via
+// GetRows up front.
+func NewImporter(fileName string, useStreamReader bool) (*Importer, error) {
+	file, err := excelize.OpenFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %w", fileName, err)
+	}
+
+	return &Importer{
+		File:            file,
+		FileName:        fileName,
+		UseStreamReader: useStreamReader,
+	}, nil
+}
+
+// Close releases the underlying excelize.File.
+func (im *Importer) Close() error {
+	return im.File.Close()
+}
+
+// overflowSheetRe matches the "_<n>" suffix Exporter appends to overflow
+// sheets once a sheet passes SheetMaxRows.
+var overflowSheetRe = regexp.MustCompile(`^(.+)_(\d+)$`)
+
+// siblingSheets returns baseName plus any "<baseName>_<n>" overflow sheets
+// present in the file, in write order, so SheetReader can re-stitch a split
+// export into a single logical stream.
+func (im *Importer) siblingSheets(baseName string) []string {
+	type suffixed struct {
+		name string
+		n    int
+	}
+
+	sheets := []suffixed{{name: baseName, n: -1}}
+	for _, name := range im.File.GetSheetList() {
+		matches := overflowSheetRe.FindStringSubmatch(name)
+		if matches == nil || matches[1] != baseName {
+			continue
+		}
+		n, err := strconv.Atoi(matches[2])
+		if err != nil {
+			continue
+		}
+		sheets = append(sheets, suffixed{name: name, n: n})
+	}
+
+	sort.Slice(sheets, func(i, j int) bool { return sheets[i].n < sheets[j].n })
+
+	names := make([]string, len(sheets))
+	for i, s := range sheets {
+		names[i] = s.name
+	}
+	return names
+}
+
+// ImportOption configures how SheetReader and ImportStructs interpret a
+// sheet's rows.
+type ImportOption func(*importConfig)
+
+type importConfig struct {
+	headerRow     bool // first row of each sheet is a header, not data; default true
+	skipBlankRows bool
+}
+
+func newImportConfig(opts ...ImportOption) *importConfig {
+	cfg := &importConfig{headerRow: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithoutHeaderRow tells SheetReader and ImportStructs that a sheet has no
+// header row, so every row (including the first) is data. By default the
+// first row of each sheet is treated as a header and skipped.
+func WithoutHeaderRow() ImportOption {
+	return func(cfg *importConfig) {
+		cfg.headerRow = false
+	}
+}
+
+// WithSkipBlankRows skips rows with no non-empty cells instead of returning
+// them, matching how spreadsheets commonly pad trailing rows.
+func WithSkipBlankRows() ImportOption {
+	return func(cfg *importConfig) {
+		cfg.skipBlankRows = true
+	}
+}
+
+// SheetReader pulls rows out of a sheet (and any "<name>_<n>" overflow
+// sheets produced by Exporter) as a single logical stream.
+type SheetReader struct {
+	importer *Importer
+	cfg      *importConfig
+
+	sheetNames []string
+	sheetIdx   int
+
+	rowsIter *excelize.Rows // used when Importer.UseStreamReader
+	buffered [][]string     // used when !Importer.UseStreamReader
+	bufIdx   int
+
+	rowNum        int // 1-based row number within the current physical sheet
+	headerPending bool
+}
+
+// NewSheetReader opens a reader over sheet (and its overflow siblings, if
+// any). The header row, if present, is consumed automatically; callers only
+// see data rows from NextRow.
+func (im *Importer) NewSheetReader(sheet string, opts ...ImportOption) (*SheetReader, error) {
+	sr := &SheetReader{
+		importer:   im,
+		cfg:        newImportConfig(opts...),
+		sheetNames: im.siblingSheets(sheet),
+	}
+
+	if err := sr.openSheet(sr.sheetNames[0]); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
+// Close releases the resources backing the reader's current physical sheet.
+// In stream mode this closes the underlying excelize.Rows iterator, which
+// otherwise leaks its on-disk temp file for the life of the process.
+func (sr *SheetReader) Close() error {
+	if sr.rowsIter != nil {
+		return sr.rowsIter.Close()
+	}
+	return nil
+}
+
+func (sr *SheetReader) openSheet(name string) error {
+	sr.rowNum = 0
+	sr.headerPending = sr.cfg.headerRow
+
+	if sr.importer.UseStreamReader {
+		rows, err := sr.importer.File.Rows(name)
+		if err != nil {
+			return fmt.Errorf("failed to read sheet %q: %w", name, err)
+		}
+		sr.rowsIter = rows
+		return nil
+	}
+
+	rows, err := sr.importer.File.GetRows(name)
+	if err != nil {
+		return fmt.Errorf("failed to read sheet %q: %w", name, err)
+	}
+	sr.buffered = rows
+	sr.bufIdx = 0
+	return nil
+}
+
+// nextRaw returns the next row's cell values as strings from the current
+// physical sheet, advancing to the next sibling sheet on exhaustion. It
+// returns io.EOF once every sibling sheet has been read.
+func (sr *SheetReader) nextRaw() ([]string, error) {
+	for {
+		row, ok, err := sr.nextRawFromCurrentSheet()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			sr.rowNum++
+			if sr.headerPending {
+				sr.headerPending = false
+				continue
+			}
+			return row, nil
+		}
+
+		sr.sheetIdx++
+		if sr.sheetIdx >= len(sr.sheetNames) {
+			return nil, io.EOF
+		}
+		if err := sr.Close(); err != nil {
+			return nil, err
+		}
+		if err := sr.openSheet(sr.sheetNames[sr.sheetIdx]); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readHeaderRow consumes and returns the current physical sheet's header
+// row directly from the already-open reader (the same excelize.Rows
+// iterator in stream mode, rather than a separate full-sheet read), so
+// ImportStructs' header lookup doesn't defeat UseStreamReader's bounded
+// memory. It must be called before any NextRow call, while the header row
+// is still pending; it is a no-op (returns nil, nil) if the sheet has no
+// header row or is empty.
+func (sr *SheetReader) readHeaderRow() ([]string, error) {
+	if !sr.headerPending {
+		return nil, nil
+	}
+
+	row, ok, err := sr.nextRawFromCurrentSheet()
+	if err != nil {
+		return nil, err
+	}
+	sr.headerPending = false
+	if !ok {
+		return nil, nil
+	}
+	sr.rowNum++
+	return row, nil
+}
+
+func (sr *SheetReader) nextRawFromCurrentSheet() ([]string, bool, error) {
+	if sr.importer.UseStreamReader {
+		if !sr.rowsIter.Next() {
+			return nil, false, sr.rowsIter.Error()
+		}
+		row, err := sr.rowsIter.Columns()
+		return row, true, err
+	}
+
+	if sr.bufIdx >= len(sr.buffered) {
+		return nil, false, nil
+	}
+	row := sr.buffered[sr.bufIdx]
+	sr.bufIdx++
+	return row, true, nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// NextRow returns the next data row, or io.EOF once the sheet and its
+// overflow siblings are exhausted. Cell.Value is always the raw string
+// excelize's Rows/GetRows return (no coercion to float64, bool, or
+// time.Time); callers that need a typed value should convert it themselves,
+// e.g. with strconv or excelize.ExcelDateToTime. ImportStructs does this
+// coercion per struct field based on its Go type — use it instead of NextRow
+// when typed values are needed.
+func (sr *SheetReader) NextRow() (Row, error) {
+	for {
+		raw, err := sr.nextRaw()
+		if err != nil {
+			return Row{}, err
+		}
+		if sr.cfg.skipBlankRows && isBlankRow(raw) {
+			continue
+		}
+
+		cells := make([]excelize.Cell, len(raw))
+		for i, value := range raw {
+			cells[i] = excelize.Cell{Value: value}
+		}
+		return Row{Cells: cells}, nil
+	}
+}
+
+// UseRowChan drains the sheet into the returned channel on a background
+// goroutine, mirroring Exporter's UseRowChan. The channel is closed when the
+// sheet is exhausted or a read error occurs; any read error is sent to errCh
+// before dataCh closes.
+func (sr *SheetReader) UseRowChan() (<-chan Row, <-chan error) {
+	dataCh := make(chan Row)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(dataCh)
+		defer close(errCh)
+
+		for {
+			row, err := sr.NextRow()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+			dataCh <- row
+		}
+	}()
+
+	return dataCh, errCh
+}
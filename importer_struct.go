@@ -0,0 +1,198 @@
+package excel_exporter
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+// ImportStructs reads sheet row by row and decodes each one into a T using
+// the same `excel:"..."` struct-tag schema as ExportStructs, calling dst for
+// every decoded value. Columns are matched to fields by their tag/field name
+// when the sheet has a header row (the default); with WithoutHeaderRow they
+// are matched positionally in schema order instead.
+func ImportStructs[T any](im *Importer, sheet string, dst func(T) error, opts ...ImportOption) error {
+	icfg := newImportConfig(opts...)
+	schema := schemaFor(reflect.TypeOf((*T)(nil)).Elem())
+
+	sr, err := im.NewSheetReader(sheet, opts...)
+	if err != nil {
+		return err
+	}
+	defer sr.Close()
+
+	colFields, err := headerColumns(sr, schema, icfg)
+	if err != nil {
+		return err
+	}
+
+	for {
+		row, err := sr.NextRow()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("excel_exporter: ImportStructs: %w", err)
+		}
+
+		var v T
+		if err := scanRowInto(reflect.ValueOf(&v).Elem(), row, colFields); err != nil {
+			return fmt.Errorf("excel_exporter: ImportStructs: %w", err)
+		}
+		if err := dst(v); err != nil {
+			return err
+		}
+	}
+}
+
+// headerColumns resolves, for each column position, which schema field (if
+// any) it maps to. With a header row present, columns are matched by the
+// header cell text against each field's name; otherwise columns map to
+// schema.fields positionally. The header row is read straight off sr's own
+// iterator (rather than a separate GetRows call) so stream mode never
+// materializes the whole sheet just to look at row one.
+func headerColumns(sr *SheetReader, schema *structSchema, icfg *importConfig) ([]*structField, error) {
+	if !icfg.headerRow {
+		fields := make([]*structField, len(schema.fields))
+		for i := range schema.fields {
+			fields[i] = &schema.fields[i]
+		}
+		return fields, nil
+	}
+
+	header, err := sr.readHeaderRow()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header row: %w", err)
+	}
+	if len(header) == 0 {
+		return nil, nil
+	}
+
+	byName := make(map[string]*structField, len(schema.fields))
+	for i := range schema.fields {
+		byName[schema.fields[i].name] = &schema.fields[i]
+	}
+
+	fields := make([]*structField, len(header))
+	for i, name := range header {
+		fields[i] = byName[strings.TrimSpace(name)]
+	}
+	return fields, nil
+}
+
+// scanRowInto decodes row's cells into dst (a struct value), using colFields
+// to map each column position to the field it belongs to, if any.
+func scanRowInto(dst reflect.Value, row Row, colFields []*structField) error {
+	for i, cell := range row.Cells {
+		if i >= len(colFields) || colFields[i] == nil {
+			continue
+		}
+
+		raw, _ := cell.Value.(string)
+		if err := setFieldValue(dst.Field(colFields[i].index), raw); err != nil {
+			return fmt.Errorf("column %q: %w", colFields[i].name, err)
+		}
+	}
+	return nil
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// setFieldValue parses raw (a cell's displayed text) into fv according to
+// fv's type, covering the same set of types convertFieldValue knows how to
+// write: time.Time, decimal.Decimal, bool, pointers, and the basic numeric
+// and string kinds.
+func setFieldValue(fv reflect.Value, raw string) error {
+	if fv.Kind() == reflect.Ptr {
+		if raw == "" {
+			return nil
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+
+	if raw == "" {
+		return nil
+	}
+
+	switch fv.Interface().(type) {
+	case time.Time:
+		t, err := parseTime(raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	case decimal.Decimal:
+		d, err := decimal.NewFromString(raw)
+		if err != nil {
+			return fmt.Errorf("invalid decimal %q: %w", raw, err)
+		}
+		fv.Set(reflect.ValueOf(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q: %w", raw, err)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", raw, err)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q: %w", raw, err)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid float %q: %w", raw, err)
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// parseTime parses raw against the layouts ExportStructs' cells are commonly
+// formatted with, falling back to treating raw as an Excel date serial
+// number for sheets read with a stream reader, where excelize hands back the
+// cell's raw numeric text rather than a formatted date string.
+func parseTime(raw string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil {
+		if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date/time %q", raw)
+}
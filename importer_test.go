@@ -0,0 +1,193 @@
+package excel_exporter
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func TestSheetReaderNextRowReturnsRawStrings(t *testing.T) {
+	fileName := "test_importer_nextrow.xlsx"
+	exporter := New(fileName, false)
+	defer os.Remove(fileName)
+
+	sheet := SheetData{
+		Name: "Sheet1",
+		RowFunc: func(*SheetContext) RowDataFunc {
+			rows := []Row{
+				NewRow("Name", "Score"),
+				NewRow("Alice", 12.5),
+				NewRow("Bob", 7),
+			}
+			idx := 0
+			return func() (Row, error) {
+				if idx >= len(rows) {
+					return Row{}, nil
+				}
+				row := rows[idx]
+				idx++
+				return row, nil
+			}
+		},
+	}
+	if err := exporter.Export([]SheetData{sheet}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	im, err := NewImporter(fileName, false)
+	if err != nil {
+		t.Fatalf("NewImporter failed: %v", err)
+	}
+	defer im.Close()
+
+	sr, err := im.NewSheetReader("Sheet1")
+	if err != nil {
+		t.Fatalf("NewSheetReader failed: %v", err)
+	}
+
+	row, err := sr.NextRow()
+	if err != nil {
+		t.Fatalf("NextRow failed: %v", err)
+	}
+	if row.Cells[0].Value != "Alice" || row.Cells[1].Value != "12.5" {
+		t.Errorf("got cells %v, want raw strings [Alice 12.5]", row.Cells)
+	}
+
+	row, err = sr.NextRow()
+	if err != nil {
+		t.Fatalf("NextRow failed: %v", err)
+	}
+	if row.Cells[0].Value != "Bob" {
+		t.Errorf("got cells %v, want [Bob ...]", row.Cells)
+	}
+
+	if _, err := sr.NextRow(); err != io.EOF {
+		t.Errorf("NextRow at end = %v, want io.EOF", err)
+	}
+}
+
+type importStructRow struct {
+	Name  string
+	Score float64
+}
+
+func TestImportStructsRoundTrip(t *testing.T) {
+	fileName := "test_import_structs.xlsx"
+	exporter := New(fileName, false)
+	defer os.Remove(fileName)
+
+	want := []importStructRow{
+		{Name: "Alice", Score: 12.5},
+		{Name: "Bob", Score: 7},
+	}
+	if err := exporter.ExportStructs("Sheet1", want); err != nil {
+		t.Fatalf("ExportStructs failed: %v", err)
+	}
+
+	im, err := NewImporter(fileName, false)
+	if err != nil {
+		t.Fatalf("NewImporter failed: %v", err)
+	}
+	defer im.Close()
+
+	var got []importStructRow
+	err = ImportStructs(im, "Sheet1", func(row importStructRow) error {
+		got = append(got, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ImportStructs failed: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name {
+			t.Errorf("row %d Name = %q, want %q", i, got[i].Name, want[i].Name)
+		}
+		if got[i].Score != want[i].Score {
+			t.Errorf("row %d Score = %v, want %v", i, got[i].Score, want[i].Score)
+		}
+	}
+}
+
+func TestImportStructsStreamMode(t *testing.T) {
+	fileName := "test_import_structs_stream.xlsx"
+	exporter := New(fileName, false)
+	defer os.Remove(fileName)
+
+	want := []importStructRow{
+		{Name: "Alice", Score: 12.5},
+		{Name: "Bob", Score: 7},
+	}
+	if err := exporter.ExportStructs("Sheet1", want); err != nil {
+		t.Fatalf("ExportStructs failed: %v", err)
+	}
+
+	im, err := NewImporter(fileName, true)
+	if err != nil {
+		t.Fatalf("NewImporter failed: %v", err)
+	}
+	defer im.Close()
+
+	var got []importStructRow
+	err = ImportStructs(im, "Sheet1", func(row importStructRow) error {
+		got = append(got, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ImportStructs failed: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name {
+			t.Errorf("row %d Name = %q, want %q", i, got[i].Name, want[i].Name)
+		}
+		if got[i].Score != want[i].Score {
+			t.Errorf("row %d Score = %v, want %v", i, got[i].Score, want[i].Score)
+		}
+	}
+}
+
+func TestSheetReaderCloseIsIdempotentWithoutStreamMode(t *testing.T) {
+	fileName := "test_importer_close.xlsx"
+	exporter := New(fileName, false)
+	defer os.Remove(fileName)
+
+	sheet := SheetData{
+		Name: "Sheet1",
+		RowFunc: func(*SheetContext) RowDataFunc {
+			rows := []Row{NewRow("Name"), NewRow("Alice")}
+			idx := 0
+			return func() (Row, error) {
+				if idx >= len(rows) {
+					return Row{}, nil
+				}
+				row := rows[idx]
+				idx++
+				return row, nil
+			}
+		},
+	}
+	if err := exporter.Export([]SheetData{sheet}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	im, err := NewImporter(fileName, true)
+	if err != nil {
+		t.Fatalf("NewImporter failed: %v", err)
+	}
+	defer im.Close()
+
+	sr, err := im.NewSheetReader("Sheet1")
+	if err != nil {
+		t.Fatalf("NewSheetReader failed: %v", err)
+	}
+	if err := sr.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
@@ -15,6 +15,26 @@ type Row struct {
 	RowOpts    []excelize.RowOpts // Options for the row, only useful when useStreamWriter is true
 }
 
+// RowDataFunc is a function type that returns the next row of data, a zero
+// Row (Cells == nil) once there is no more data, or an error that should
+// abort the export in progress.
+type RowDataFunc func() (Row, error)
+
+// SheetRowFunc builds the RowDataFunc that produces a sheet's rows, given
+// that sheet's SheetContext. Exporter no longer exposes CurrentSheet or
+// StreamWriter fields, since ExportConcurrent may be writing several sheets
+// at once; callbacks that need to know their sheet name or write to its
+// StreamWriter (e.g. to set column widths) get them from ctx instead.
+type SheetRowFunc func(ctx *SheetContext) RowDataFunc
+
+// SheetData represents the data for a single sheet.
+type SheetData struct {
+	Name    string
+	RowFunc SheetRowFunc
+	Table   *TableSpec    // optional: render the sheet's data as an Excel Table
+	Options *SheetOptions // optional: freeze panes, autofilter, data validation, conditional formatting
+}
+
 // NewRow creates a new Row with the specified cell values.
 func NewRow(cellValues ...interface{}) Row {
 	cells := make([]excelize.Cell, len(cellValues))
@@ -0,0 +1,258 @@
+package excel_exporter
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SheetOptions exposes the high-level spreadsheet knobs that would otherwise
+// require reaching into Exporter.File by hand: frozen panes, an autofilter,
+// data validation, and conditional formatting.
+type SheetOptions struct {
+	FreezePanes        *FreezePanes
+	AutoFilter         *AutoFilter
+	DataValidations    []DataValidation
+	ConditionalFormats []ConditionalFormat
+}
+
+// FreezePanes freezes Rows leading rows and Cols leading columns.
+type FreezePanes struct {
+	Rows int
+	Cols int
+}
+
+// AutoFilter adds filter buttons over Range, or over the written header row
+// (row 1, spanning every written column) when Range is empty.
+type AutoFilter struct {
+	Range string
+}
+
+// DataValidationType selects which kind of constraint a DataValidation
+// enforces.
+type DataValidationType string
+
+const (
+	DataValidationList   DataValidationType = "list"        // Values is the allowed set
+	DataValidationWhole  DataValidationType = "whole_number" // [Min, Max] inclusive
+	DataValidationDate   DataValidationType = "date"         // [MinDate, MaxDate] inclusive
+	DataValidationCustom DataValidationType = "custom"       // Formula must evaluate to TRUE
+)
+
+// DataValidation constrains the values a user can enter into Range, with an
+// optional error message (shown when a constraint is violated) and prompt
+// message (shown when the cell is selected).
+type DataValidation struct {
+	Range string
+	Type  DataValidationType
+
+	Values           []string // DataValidationList
+	Min, Max         float64  // DataValidationWhole
+	MinDate, MaxDate string   // DataValidationDate, as excelize expects (e.g. "2026-01-01")
+	Formula          string   // DataValidationCustom
+
+	ErrorTitle, ErrorMsg   string
+	PromptTitle, PromptMsg string
+}
+
+// ConditionalFormatType selects which excelize conditional-format rule a
+// ConditionalFormat resolves to.
+type ConditionalFormatType string
+
+const (
+	ConditionalFormatColorScale ConditionalFormatType = "color_scale"
+	ConditionalFormatDataBar    ConditionalFormatType = "data_bar"
+	ConditionalFormatCellValue  ConditionalFormatType = "cell_value"
+)
+
+// ConditionalFormat highlights cells in Range that match Type's rule.
+type ConditionalFormat struct {
+	Range string
+	Type  ConditionalFormatType
+
+	Colors []string // ConditionalFormatColorScale (2 or 3 stops) / ConditionalFormatDataBar (1)
+
+	Criteria string // ConditionalFormatCellValue: e.g. ">", "<", "between"
+	Value    string // ConditionalFormatCellValue: comparison value, or lower bound when Criteria is "between"
+	MaxValue string // ConditionalFormatCellValue: upper bound when Criteria is "between"
+	Style    *excelize.Style
+}
+
+// applySheetDataValidations must run before a stream-mode sheet's
+// StreamWriter is flushed: excelize folds data validations into the
+// worksheet content the stream writer assembles, so adding them afterward
+// would be silently lost. FreezePanes, AutoFilter, and ConditionalFormats,
+// by contrast, are stored as separate parts layered onto the finished sheet
+// and must run after Flush instead (see applySheetOptions).
+func applySheetDataValidations(mu *sync.Mutex, file *excelize.File, sheetName string, validations []DataValidation) error {
+	if len(validations) == 0 {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, v := range validations {
+		dv, err := buildDataValidation(v)
+		if err != nil {
+			return err
+		}
+		if err := file.AddDataValidation(sheetName, dv); err != nil {
+			return fmt.Errorf("excel_exporter: data validation %q: %w", v.Range, err)
+		}
+	}
+	return nil
+}
+
+func buildDataValidation(v DataValidation) (*excelize.DataValidation, error) {
+	dv := excelize.NewDataValidation(true)
+	dv.Sqref = v.Range
+
+	switch v.Type {
+	case DataValidationList:
+		if err := dv.SetDropList(v.Values); err != nil {
+			return nil, fmt.Errorf("excel_exporter: data validation %q: %w", v.Range, err)
+		}
+	case DataValidationWhole:
+		dv.Type = "whole"
+		dv.Operator = "between"
+		dv.Formula1 = strconv.FormatFloat(v.Min, 'f', -1, 64)
+		dv.Formula2 = strconv.FormatFloat(v.Max, 'f', -1, 64)
+	case DataValidationDate:
+		dv.Type = "date"
+		dv.Operator = "between"
+		dv.Formula1 = v.MinDate
+		dv.Formula2 = v.MaxDate
+	case DataValidationCustom:
+		dv.Type = "custom"
+		dv.Formula1 = v.Formula
+	default:
+		return nil, fmt.Errorf("excel_exporter: unknown DataValidationType %q", v.Type)
+	}
+
+	if v.ErrorMsg != "" {
+		dv.SetError(excelize.DataValidationErrorStyleStop, v.ErrorTitle, v.ErrorMsg)
+	}
+	if v.PromptMsg != "" {
+		dv.SetInput(v.PromptTitle, v.PromptMsg)
+	}
+
+	return dv, nil
+}
+
+// applySheetOptions applies the options that, unlike DataValidations, must
+// run after a stream-mode sheet has been flushed. cols is the column count
+// exportHelper wrote, used when opts.AutoFilter.Range is left to
+// auto-detect from the header.
+func applySheetOptions(mu *sync.Mutex, file *excelize.File, sheetName string, opts *SheetOptions, cols int) error {
+	if opts == nil {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := applyFreezePanes(file, sheetName, opts.FreezePanes); err != nil {
+		return err
+	}
+	if err := applyAutoFilter(file, sheetName, opts.AutoFilter, cols); err != nil {
+		return err
+	}
+	return applyConditionalFormats(file, sheetName, opts.ConditionalFormats)
+}
+
+func applyFreezePanes(file *excelize.File, sheetName string, fp *FreezePanes) error {
+	if fp == nil || (fp.Rows == 0 && fp.Cols == 0) {
+		return nil
+	}
+
+	topLeft, err := excelize.CoordinatesToCellName(fp.Cols+1, fp.Rows+1)
+	if err != nil {
+		return err
+	}
+
+	activePane := "bottomRight"
+	switch {
+	case fp.Rows > 0 && fp.Cols == 0:
+		activePane = "bottomLeft"
+	case fp.Rows == 0 && fp.Cols > 0:
+		activePane = "topRight"
+	}
+
+	return file.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      fp.Cols,
+		YSplit:      fp.Rows,
+		TopLeftCell: topLeft,
+		ActivePane:  activePane,
+	})
+}
+
+func applyAutoFilter(file *excelize.File, sheetName string, af *AutoFilter, cols int) error {
+	if af == nil {
+		return nil
+	}
+
+	rng := af.Range
+	if rng == "" {
+		if cols == 0 {
+			return nil
+		}
+		lastCol, err := excelize.ColumnNumberToName(cols)
+		if err != nil {
+			return err
+		}
+		rng = fmt.Sprintf("A1:%s1", lastCol)
+	}
+
+	return file.AutoFilter(sheetName, rng, nil)
+}
+
+func applyConditionalFormats(file *excelize.File, sheetName string, formats []ConditionalFormat) error {
+	for _, cf := range formats {
+		opt := excelize.ConditionalFormatOptions{Criteria: cf.Criteria}
+		if opt.Criteria == "" {
+			opt.Criteria = "="
+		}
+
+		switch cf.Type {
+		case ConditionalFormatColorScale:
+			opt.Type = fmt.Sprintf("%d_color_scale", len(cf.Colors))
+			if len(cf.Colors) > 0 {
+				opt.MinType, opt.MinColor = "min", cf.Colors[0]
+			}
+			if len(cf.Colors) == 3 {
+				opt.MidType, opt.MidColor = "percentile", cf.Colors[1]
+			}
+			if len(cf.Colors) > 1 {
+				opt.MaxType, opt.MaxColor = "max", cf.Colors[len(cf.Colors)-1]
+			}
+		case ConditionalFormatDataBar:
+			opt.Type = "data_bar"
+			opt.MinType, opt.MaxType = "min", "max"
+			if len(cf.Colors) > 0 {
+				opt.BarColor = cf.Colors[0]
+			}
+		case ConditionalFormatCellValue:
+			opt.Type = "cell"
+			opt.Value = cf.Value
+			opt.MaxValue = cf.MaxValue
+			if cf.Style != nil {
+				styleID, err := file.NewConditionalStyle(cf.Style)
+				if err != nil {
+					return fmt.Errorf("excel_exporter: conditional format %q: %w", cf.Range, err)
+				}
+				opt.Format = styleID
+			}
+		default:
+			return fmt.Errorf("excel_exporter: unknown ConditionalFormatType %q", cf.Type)
+		}
+
+		if err := file.SetConditionalFormat(sheetName, cf.Range, []excelize.ConditionalFormatOptions{opt}); err != nil {
+			return fmt.Errorf("excel_exporter: conditional format %q: %w", cf.Range, err)
+		}
+	}
+	return nil
+}
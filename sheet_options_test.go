@@ -0,0 +1,120 @@
+package excel_exporter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func exportSheetWithOptions(t *testing.T, fileName string, useStreamWriter bool, opts *SheetOptions) *excelize.File {
+	t.Helper()
+
+	exporter := New(fileName, useStreamWriter)
+	sheet := SheetData{
+		Name: "Sheet1",
+		RowFunc: func(*SheetContext) RowDataFunc {
+			rows := []Row{
+				NewRow("Name", "Qty"),
+				NewRow("Widget", 3.0),
+				NewRow("Gadget", 5.0),
+			}
+			idx := 0
+			return func() (Row, error) {
+				if idx >= len(rows) {
+					return Row{}, nil
+				}
+				row := rows[idx]
+				idx++
+				return row, nil
+			}
+		},
+		Options: opts,
+	}
+
+	if err := exporter.Export([]SheetData{sheet}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	return exporter.File
+}
+
+func TestSheetOptionsFreezePanesAndAutoFilter(t *testing.T) {
+	for _, useStreamWriter := range []bool{true, false} {
+		fileName := "test_sheet_options_panes.xlsx"
+		f := exportSheetWithOptions(t, fileName, useStreamWriter, &SheetOptions{
+			FreezePanes: &FreezePanes{Rows: 1},
+			AutoFilter:  &AutoFilter{},
+		})
+		os.Remove(fileName)
+
+		// AutoFilter has no excelize getter; exportSheetWithOptions already
+		// fails the test via Export if applyAutoFilter returned an error.
+		panes, err := f.GetPanes("Sheet1")
+		if err != nil {
+			t.Fatalf("[stream=%v] GetPanes: %v", useStreamWriter, err)
+		}
+		if !panes.Freeze {
+			t.Errorf("[stream=%v] expected a frozen pane, got %+v", useStreamWriter, panes)
+		}
+	}
+}
+
+func TestSheetOptionsDataValidation(t *testing.T) {
+	for _, useStreamWriter := range []bool{true, false} {
+		fileName := "test_sheet_options_dv.xlsx"
+		f := exportSheetWithOptions(t, fileName, useStreamWriter, &SheetOptions{
+			DataValidations: []DataValidation{
+				{
+					Range:      "B2:B3",
+					Type:       DataValidationWhole,
+					Min:        0,
+					Max:        100,
+					ErrorTitle: "Invalid",
+					ErrorMsg:   "Must be between 0 and 100",
+				},
+			},
+		})
+		os.Remove(fileName)
+
+		dvs, err := f.GetDataValidations("Sheet1")
+		if err != nil {
+			t.Fatalf("[stream=%v] GetDataValidations: %v", useStreamWriter, err)
+		}
+		if len(dvs) != 1 {
+			t.Fatalf("[stream=%v] got %d data validations, want 1", useStreamWriter, len(dvs))
+		}
+		if dvs[0].Sqref != "B2:B3" {
+			t.Errorf("[stream=%v] Sqref = %q, want %q", useStreamWriter, dvs[0].Sqref, "B2:B3")
+		}
+		if dvs[0].Type != "whole" {
+			t.Errorf("[stream=%v] Type = %q, want %q", useStreamWriter, dvs[0].Type, "whole")
+		}
+	}
+}
+
+func TestSheetOptionsConditionalFormat(t *testing.T) {
+	fileName := "test_sheet_options_cf.xlsx"
+	f := exportSheetWithOptions(t, fileName, false, &SheetOptions{
+		ConditionalFormats: []ConditionalFormat{
+			{
+				Range:    "B2:B3",
+				Type:     ConditionalFormatCellValue,
+				Criteria: ">",
+				Value:    "4",
+			},
+		},
+	})
+	defer os.Remove(fileName)
+
+	byRange, err := f.GetConditionalFormats("Sheet1")
+	if err != nil {
+		t.Fatalf("GetConditionalFormats: %v", err)
+	}
+	opts := byRange["B2:B3"]
+	if len(opts) != 1 {
+		t.Fatalf("got %d conditional formats for B2:B3, want 1", len(opts))
+	}
+	if opts[0].Criteria != "greater than" || opts[0].Value != "4" {
+		t.Errorf("got %+v, want Criteria=\"greater than\" Value=\"4\"", opts[0])
+	}
+}
@@ -0,0 +1,392 @@
+package excel_exporter
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+// structTagKey is the struct tag used to drive column mapping for ExportStructs.
+const structTagKey = "excel"
+
+// tagTokenRe matches "key=" boundaries inside a struct tag so values are free
+// to contain commas (e.g. format=#,##0.00).
+var tagTokenRe = regexp.MustCompile(`(?:^|,)(\w+)=`)
+
+// StructOption configures how ExportStructs and StructRowFunc render structs.
+type StructOption func(*structConfig)
+
+type structConfig struct {
+	styles          map[string]excelize.Style
+	resolvedStyleID map[string]int
+	frozenHeader    bool
+}
+
+// WithStyle registers a named style that fields can reference via their
+// `style=<name>` tag, and that the header row uses under the name "header".
+func WithStyle(name string, style excelize.Style) StructOption {
+	return func(cfg *structConfig) {
+		cfg.styles[name] = style
+	}
+}
+
+// WithFrozenHeader freezes the header row once the sheet has been written.
+func WithFrozenHeader() StructOption {
+	return func(cfg *structConfig) {
+		cfg.frozenHeader = true
+	}
+}
+
+func newStructConfig(opts ...StructOption) *structConfig {
+	cfg := &structConfig{
+		styles:          make(map[string]excelize.Style),
+		resolvedStyleID: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// resolveStyleID resolves a named style to an excelize style ID exactly once
+// per (file, name) pair, caching the result on the config.
+func (cfg *structConfig) resolveStyleID(file *excelize.File, name string) int {
+	if name == "" || file == nil {
+		return 0
+	}
+
+	style, ok := cfg.styles[name]
+	if !ok {
+		return 0
+	}
+
+	if id, ok := cfg.resolvedStyleID[name]; ok {
+		return id
+	}
+
+	id, err := file.NewStyle(&style)
+	if err != nil {
+		return 0
+	}
+	cfg.resolvedStyleID[name] = id
+	return id
+}
+
+// resolveFieldStyleID resolves a column's style, layering its `format=` number
+// format onto its named `style=` (if any), caching the result per (style,
+// format) pair.
+func (cfg *structConfig) resolveFieldStyleID(file *excelize.File, field structField) int {
+	if file == nil || (field.style == "" && field.format == "") {
+		return 0
+	}
+
+	key := field.style + "\x00" + field.format
+	if id, ok := cfg.resolvedStyleID[key]; ok {
+		return id
+	}
+
+	style := cfg.styles[field.style] // zero value if no named style was registered
+	if field.format != "" {
+		style.CustomNumFmt = &field.format
+	}
+
+	id, err := file.NewStyle(&style)
+	if err != nil {
+		return 0
+	}
+	cfg.resolvedStyleID[key] = id
+	return id
+}
+
+// structField describes how a single struct field maps to a column.
+type structField struct {
+	index  int
+	name   string
+	order  int
+	width  float64
+	format string
+	style  string
+}
+
+// structSchema is the column layout for a struct type, cached per reflect.Type
+// so repeated exports of the same type don't re-walk its fields or re-parse tags.
+type structSchema struct {
+	typ    reflect.Type
+	fields []structField
+}
+
+var schemaCache sync.Map // map[reflect.Type]*structSchema
+
+func schemaFor(typ reflect.Type) *structSchema {
+	if cached, ok := schemaCache.Load(typ); ok {
+		return cached.(*structSchema)
+	}
+
+	actual, _ := schemaCache.LoadOrStore(typ, buildSchema(typ))
+	return actual.(*structSchema)
+}
+
+func buildSchema(typ reflect.Type) *structSchema {
+	schema := &structSchema{typ: typ}
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		field := structField{
+			index: i,
+			name:  sf.Name,
+			order: len(schema.fields),
+		}
+
+		if tag, ok := sf.Tag.Lookup(structTagKey); ok {
+			if parseStructTag(tag, &field) {
+				continue // skip=true
+			}
+		}
+
+		schema.fields = append(schema.fields, field)
+	}
+
+	sort.SliceStable(schema.fields, func(i, j int) bool {
+		return schema.fields[i].order < schema.fields[j].order
+	})
+
+	return schema
+}
+
+// parseStructTag applies the key=value pairs in tag to field and reports
+// whether the field should be skipped.
+func parseStructTag(tag string, field *structField) (skip bool) {
+	keys := tagTokenRe.FindAllStringSubmatchIndex(tag, -1)
+	for i, loc := range keys {
+		key := tag[loc[2]:loc[3]]
+		valStart := loc[1]
+		valEnd := len(tag)
+		if i+1 < len(keys) {
+			valEnd = keys[i+1][0]
+		}
+		value := strings.TrimSuffix(tag[valStart:valEnd], ",")
+
+		switch key {
+		case "name":
+			field.name = value
+		case "order":
+			if n, err := strconv.Atoi(value); err == nil {
+				field.order = n
+			}
+		case "width":
+			if w, err := strconv.ParseFloat(value, 64); err == nil {
+				field.width = w
+			}
+		case "format":
+			field.format = value
+		case "style":
+			field.style = value
+		case "skip":
+			skip, _ = strconv.ParseBool(value)
+		}
+	}
+	return skip
+}
+
+// headerRow builds the header row for the schema, applying the style
+// registered under the name "header", if any. file resolves that style
+// against the sheet being written; pass nil only when no header style
+// could possibly apply (there is none for generator-based rows).
+func (s *structSchema) headerRow(file *excelize.File, cfg *structConfig) Row {
+	styleID := cfg.resolveStyleID(file, "header")
+
+	cells := make([]excelize.Cell, len(s.fields))
+	for i, field := range s.fields {
+		cells[i] = excelize.Cell{Value: field.name, StyleID: styleID}
+	}
+	return Row{Cells: cells}
+}
+
+// rowFor converts one struct value into a Row using the schema's column layout.
+func (s *structSchema) rowFor(file *excelize.File, cfg *structConfig, v reflect.Value) Row {
+	cells := make([]excelize.Cell, len(s.fields))
+	for i, field := range s.fields {
+		cells[i] = excelize.Cell{
+			Value:   convertFieldValue(v.Field(field.index)),
+			StyleID: cfg.resolveFieldStyleID(file, field),
+		}
+	}
+	return Row{Cells: cells}
+}
+
+// convertFieldValue converts a struct field's reflect.Value into a value
+// excelize can write as a cell value.
+func convertFieldValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value
+	case decimal.Decimal:
+		return value.InexactFloat64()
+	case fmt.Stringer:
+		return value.String()
+	}
+
+	return v.Interface()
+}
+
+// applyColWidths sets each field's configured column width on the sheet
+// currently being written, the same way queryDataToChannelFunc does by hand.
+// It runs from inside a RowDataFunc rather than exporter.go's own
+// mu-guarded writeRowFunc, so it takes ctx.Mu itself to stay safe against
+// another goroutine's concurrent File/StreamWriter access from
+// ExportConcurrent.
+func applyColWidths(ctx *SheetContext, schema *structSchema) error {
+	ctx.Mu.Lock()
+	defer ctx.Mu.Unlock()
+
+	for i, field := range schema.fields {
+		if field.width <= 0 {
+			continue
+		}
+
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+
+		if ctx.UseStreamWriter {
+			err = ctx.StreamWriter.SetColWidth(i+1, i+1, field.width)
+		} else {
+			err = ctx.File.SetColWidth(ctx.Name, col, col, field.width)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportStructs exports rows, a slice of structs (or a SheetRowFunc built with
+// StructRowFunc), to sheetName in one call. Column order, header text, number
+// format, width, and whether to skip a field are driven by `excel:"..."`
+// struct tags; fields without tags become columns in declaration order with
+// their Go field name as the header.
+func (e *Exporter) ExportStructs(sheetName string, rows any, opts ...StructOption) error {
+	cfg := newStructConfig(opts...)
+
+	var rowFunc SheetRowFunc
+	if prebuilt, ok := rows.(SheetRowFunc); ok {
+		rowFunc = prebuilt
+	} else {
+		var err error
+		rowFunc, err = structSliceRowFunc(cfg, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := e.Export([]SheetData{{Name: sheetName, RowFunc: rowFunc}}); err != nil {
+		return err
+	}
+
+	if !cfg.frozenHeader {
+		return nil
+	}
+
+	return e.File.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+}
+
+func structSliceRowFunc(cfg *structConfig, rows any) (SheetRowFunc, error) {
+	val := reflect.ValueOf(rows)
+	if val.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("excel_exporter: ExportStructs: rows must be a slice or a RowDataFunc, got %T", rows)
+	}
+
+	schema := schemaFor(val.Type().Elem())
+
+	return func(ctx *SheetContext) RowDataFunc {
+		idx := 0
+		headerSent := false
+		return func() (Row, error) {
+			if !headerSent {
+				headerSent = true
+				if err := applyColWidths(ctx, schema); err != nil {
+					return Row{}, fmt.Errorf("excel_exporter: ExportStructs: %w", err)
+				}
+				return schema.headerRow(ctx.File, cfg), nil
+			}
+			if idx >= val.Len() {
+				return Row{}, nil
+			}
+			row := schema.rowFor(ctx.File, cfg, val.Index(idx))
+			idx++
+			return row, nil
+		}
+	}, nil
+}
+
+// StructRowFunc adapts a (T, bool, error) generator, such as a database
+// cursor, into a SheetRowFunc that feeds the same struct-tag column mapping
+// as ExportStructs without materializing a slice. Returning a SheetRowFunc,
+// rather than a bare RowDataFunc, gives the returned rows access to the
+// sheet's real *excelize.File via SheetContext, so `format=`/`style=` (with
+// styles registered through opts) and `width=` tags are honored exactly as
+// they are for ExportStructs. The RowDataFunc it builds emits a header row
+// on its first call; an error from next aborts the export via RowDataFunc's
+// own error return.
+//
+// WithFrozenHeader has no effect here: freezing panes is a post-write
+// operation ExportStructs applies to the finished sheet, but a SheetRowFunc
+// built by StructRowFunc is opaque to ExportStructs, which has no way to
+// learn this cfg's frozenHeader setting back out. Passing it panics rather
+// than silently writing a sheet whose header isn't actually frozen; pass
+// WithFrozenHeader to ExportStructs itself instead.
+func StructRowFunc[T any](next func() (T, bool, error), opts ...StructOption) SheetRowFunc {
+	schema := schemaFor(reflect.TypeOf((*T)(nil)).Elem())
+	cfg := newStructConfig(opts...)
+	if cfg.frozenHeader {
+		panic("excel_exporter: WithFrozenHeader has no effect on StructRowFunc; pass it to ExportStructs instead")
+	}
+
+	return func(ctx *SheetContext) RowDataFunc {
+		headerSent := false
+		return func() (Row, error) {
+			if !headerSent {
+				headerSent = true
+				if err := applyColWidths(ctx, schema); err != nil {
+					return Row{}, fmt.Errorf("excel_exporter: StructRowFunc: %w", err)
+				}
+				return schema.headerRow(ctx.File, cfg), nil
+			}
+
+			row, ok, err := next()
+			if err != nil {
+				return Row{}, fmt.Errorf("excel_exporter: struct row generator: %w", err)
+			}
+			if !ok {
+				return Row{}, nil
+			}
+
+			return schema.rowFor(ctx.File, cfg, reflect.ValueOf(row)), nil
+		}
+	}
+}
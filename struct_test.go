@@ -0,0 +1,114 @@
+package excel_exporter
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type structExportRow struct {
+	Name  string  `excel:"name=Full Name,order=1"`
+	Total float64 `excel:"order=2,format=#,##0.00,style=money,width=20"`
+	Skip  string  `excel:"skip=true"`
+}
+
+func TestExportStructsAppliesHeaderStyleAndFieldTags(t *testing.T) {
+	exporter := New("test_export_structs.xlsx", false)
+	defer os.Remove(exporter.FileName)
+
+	rows := []structExportRow{
+		{Name: "Alice", Total: 12.5},
+		{Name: "Bob", Total: 7},
+	}
+
+	err := exporter.ExportStructs("Sheet1", rows,
+		WithStyle("header", excelize.Style{Font: &excelize.Font{Bold: true}}),
+		WithStyle("money", excelize.Style{}),
+	)
+	if err != nil {
+		t.Fatalf("ExportStructs failed: %v", err)
+	}
+
+	f := exporter.File
+
+	headerStyle, err := f.GetCellStyle("Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("GetCellStyle(A1): %v", err)
+	}
+	if headerStyle == 0 {
+		t.Errorf("header cell A1 has no style applied; WithStyle(\"header\", ...) should have resolved one")
+	}
+
+	name, err := f.GetCellValue("Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("GetCellValue(A1): %v", err)
+	}
+	if name != "Full Name" {
+		t.Errorf("header A1 = %q, want %q", name, "Full Name")
+	}
+
+	totalStyle, err := f.GetCellStyle("Sheet1", "B2")
+	if err != nil {
+		t.Fatalf("GetCellStyle(B2): %v", err)
+	}
+	if totalStyle == 0 {
+		t.Errorf("Total cell B2 has no style applied; format=/style= tags should have resolved one")
+	}
+
+	width, err := f.GetColWidth("Sheet1", "B")
+	if err != nil {
+		t.Fatalf("GetColWidth(B): %v", err)
+	}
+	if width != 20 {
+		t.Errorf("column B width = %v, want 20 (from width= tag)", width)
+	}
+}
+
+func TestStructRowFuncAppliesHeaderStyleAndFieldTags(t *testing.T) {
+	exporter := New("test_struct_row_func.xlsx", false)
+	defer os.Remove(exporter.FileName)
+
+	data := []structExportRow{
+		{Name: "Carol", Total: 3},
+		{Name: "Dave", Total: 9.9},
+	}
+	idx := 0
+	next := func() (structExportRow, bool, error) {
+		if idx >= len(data) {
+			return structExportRow{}, false, nil
+		}
+		row := data[idx]
+		idx++
+		return row, true, nil
+	}
+
+	err := exporter.ExportStructs("Sheet1",
+		StructRowFunc(next, WithStyle("header", excelize.Style{Font: &excelize.Font{Bold: true}})),
+	)
+	if err != nil {
+		t.Fatalf("ExportStructs with StructRowFunc failed: %v", err)
+	}
+
+	f := exporter.File
+
+	headerStyle, err := f.GetCellStyle("Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("GetCellStyle(A1): %v", err)
+	}
+	if headerStyle == 0 {
+		t.Errorf("header cell A1 has no style applied; StructRowFunc should resolve the header style against the real file")
+	}
+
+	for i, want := range []string{"Carol", "Dave"} {
+		cell := fmt.Sprintf("A%d", i+2)
+		got, err := f.GetCellValue("Sheet1", cell)
+		if err != nil {
+			t.Fatalf("GetCellValue(%s): %v", cell, err)
+		}
+		if got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package excel_exporter
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TableColumnTotal describes the totals-row formula for one column of a
+// TableSpec, addressed by its column letter (e.g. "C").
+type TableColumnTotal struct {
+	Col     string // column letter the total applies to
+	Func    string // "sum", "average", "count", or "" to use Formula as-is
+	Formula string // custom formula, used when Func is "" or "custom"
+}
+
+// TableSpec turns a sheet's data into a real Excel Table via excelize's
+// AddTable, instead of a plain range of cells.
+type TableSpec struct {
+	Name              string
+	StyleName         string // e.g. "TableStyleMedium2"
+	Range             string // explicit "A1:D10"; auto-detected from the written rows when empty
+	ShowHeaderRow     *bool
+	ShowFirstColumn   bool
+	ShowLastColumn    bool
+	ShowRowStripes    *bool
+	ShowColumnStripes bool
+
+	// Totals appends a totals row below the data, one formula per column.
+	//
+	// The excelize version this module is built against doesn't expose a
+	// table's totalsRowShown flag, so the row is written as plain SUBTOTAL/
+	// custom formulas rather than a native totals region; it still computes
+	// and displays correctly, it just isn't styled as part of the table band.
+	Totals []TableColumnTotal
+}
+
+// applyTable wraps a sheet's written data in an Excel Table. rows and cols
+// are the row/column counts exportHelper wrote to the sheet's primary range;
+// a table needs a header plus at least one data row, so smaller sheets are
+// left alone. mu guards the shared *excelize.File, since ExportConcurrent
+// may be applying another sheet's table at the same time.
+func (e *Exporter) applyTable(mu *sync.Mutex, sheetName string, spec *TableSpec, rows, cols int) error {
+	if spec == nil || rows < 2 || cols == 0 {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	dataRange := spec.Range
+	if dataRange == "" {
+		lastCol, err := excelize.ColumnNumberToName(cols)
+		if err != nil {
+			return err
+		}
+		dataRange = fmt.Sprintf("A1:%s%d", lastCol, rows)
+	}
+
+	table := &excelize.Table{
+		Range:             dataRange,
+		Name:              spec.Name,
+		StyleName:         spec.StyleName,
+		ShowHeaderRow:     spec.ShowHeaderRow,
+		ShowFirstColumn:   spec.ShowFirstColumn,
+		ShowLastColumn:    spec.ShowLastColumn,
+		ShowRowStripes:    spec.ShowRowStripes,
+		ShowColumnStripes: spec.ShowColumnStripes,
+	}
+	if err := e.File.AddTable(sheetName, table); err != nil {
+		return fmt.Errorf("failed to add table to sheet %q: %w", sheetName, err)
+	}
+
+	return e.applyTableTotals(sheetName, spec, rows)
+}
+
+// applyTableTotals writes one SUBTOTAL (or custom) formula per configured
+// column in the row directly below the table's data.
+func (e *Exporter) applyTableTotals(sheetName string, spec *TableSpec, lastDataRow int) error {
+	if len(spec.Totals) == 0 {
+		return nil
+	}
+
+	totalsRow := lastDataRow + 1
+	for _, total := range spec.Totals {
+		formula := total.Formula
+		switch total.Func {
+		case "sum":
+			formula = fmt.Sprintf("SUBTOTAL(109,%s2:%s%d)", total.Col, total.Col, lastDataRow)
+		case "average":
+			formula = fmt.Sprintf("SUBTOTAL(101,%s2:%s%d)", total.Col, total.Col, lastDataRow)
+		case "count":
+			formula = fmt.Sprintf("SUBTOTAL(103,%s2:%s%d)", total.Col, total.Col, lastDataRow)
+		}
+		if formula == "" {
+			continue
+		}
+
+		cell := fmt.Sprintf("%s%d", total.Col, totalsRow)
+		if err := e.File.SetCellFormula(sheetName, cell, formula); err != nil {
+			return fmt.Errorf("failed to set totals formula for column %q: %w", total.Col, err)
+		}
+	}
+
+	return nil
+}
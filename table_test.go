@@ -0,0 +1,96 @@
+package excel_exporter
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExportWithTableAppliesTableAndTotals(t *testing.T) {
+	exporter := New("test_table.xlsx", false)
+	defer os.Remove(exporter.FileName)
+
+	sheet := SheetData{
+		Name: "Sheet1",
+		RowFunc: func(*SheetContext) RowDataFunc {
+			rows := []Row{
+				NewRow("Item", "Qty"),
+				NewRow("Widget", 3.0),
+				NewRow("Gadget", 5.0),
+			}
+			idx := 0
+			return func() (Row, error) {
+				if idx >= len(rows) {
+					return Row{}, nil
+				}
+				row := rows[idx]
+				idx++
+				return row, nil
+			}
+		},
+		Table: &TableSpec{
+			Name:      "ItemsTable",
+			StyleName: "TableStyleMedium2",
+			Totals: []TableColumnTotal{
+				{Col: "B", Func: "sum"},
+			},
+		},
+	}
+
+	if err := exporter.Export([]SheetData{sheet}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	tables, err := exporter.File.GetTables("Sheet1")
+	if err != nil {
+		t.Fatalf("GetTables: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+	if tables[0].Name != "ItemsTable" {
+		t.Errorf("table name = %q, want %q", tables[0].Name, "ItemsTable")
+	}
+	if tables[0].Range != "A1:B3" {
+		t.Errorf("table range = %q, want %q", tables[0].Range, "A1:B3")
+	}
+
+	formula, err := exporter.File.GetCellFormula("Sheet1", "B4")
+	if err != nil {
+		t.Fatalf("GetCellFormula(B4): %v", err)
+	}
+	if formula != "SUBTOTAL(109,B2:B3)" {
+		t.Errorf("totals formula = %q, want %q", formula, "SUBTOTAL(109,B2:B3)")
+	}
+}
+
+func TestExportWithTableSkipsSheetsTooSmallForATable(t *testing.T) {
+	exporter := New("test_table_small.xlsx", false)
+	defer os.Remove(exporter.FileName)
+
+	sheet := SheetData{
+		Name: "Sheet1",
+		RowFunc: func(*SheetContext) RowDataFunc {
+			sent := false
+			return func() (Row, error) {
+				if sent {
+					return Row{}, nil
+				}
+				sent = true
+				return NewRow("Item", "Qty"), nil
+			}
+		},
+		Table: &TableSpec{Name: "ItemsTable"},
+	}
+
+	if err := exporter.Export([]SheetData{sheet}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	tables, err := exporter.File.GetTables("Sheet1")
+	if err != nil {
+		t.Fatalf("GetTables: %v", err)
+	}
+	if len(tables) != 0 {
+		t.Errorf("got %d tables for a header-only sheet, want 0", len(tables))
+	}
+}